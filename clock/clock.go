@@ -2,11 +2,23 @@ package clock
 
 import "time"
 
+// PassiveClock defines an API for accessing the current time without being
+// able to schedule timeouts against it. Code that only needs to read time
+// (e.g. stamping Raft log entries) should depend on this narrower interface
+// rather than the full Clock, matching the layering used by Kubernetes'
+// apimachinery/util/clock package.
+type PassiveClock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Since returns the elapsed time since t.
+	Since(t time.Time) time.Duration
+}
+
 // Clock defines an API for accessing the current time and for implementing
 // timeouts / timers.
 type Clock interface {
-	// Now returns the current time.
-	Now() time.Time
+	PassiveClock
 
 	// After waits for the duration to elapse and then sends the current time on
 	// the returned channel.
@@ -15,6 +27,18 @@ type Clock interface {
 	// NewTimer creates a new Timer that will send the current time on its
 	// channel after at least duration d.
 	NewTimer(time.Duration) Timer
+
+	// NewTicker creates a new Ticker that will send the current time on its
+	// channel every duration d until stopped.
+	NewTicker(time.Duration) Ticker
+
+	// NewAlarm creates a new Alarm scheduled to fire at time t.
+	NewAlarm(t time.Time) Alarm
+
+	// AfterFunc waits for the duration to elapse and then calls f in its own
+	// goroutine. It returns a Timer that can be used to cancel the call
+	// using its Stop method.
+	AfterFunc(d time.Duration, f func()) Timer
 }
 
 // Timer defines an API for accessing a timer obtained via a clock instance.
@@ -31,3 +55,41 @@ type Timer interface {
 	// channel channel before calling Reset().
 	Stop() bool
 }
+
+// Ticker defines an API for accessing a ticker obtained via a clock instance.
+type Ticker interface {
+	// C returns a channel where the ticker will send the current time on
+	// every tick.
+	C() <-chan time.Time
+
+	// Reset the ticker so it ticks every duration d, starting from now.
+	Reset(time.Duration)
+
+	// Stop the ticker from ticking. Unlike Timer.Stop, Stop does not report
+	// whether the ticker was active.
+	Stop()
+}
+
+// Alarm defines an API for scheduling a wakeup at an absolute point in time
+// that can be pulled inward (fired sooner) but never pushed outward by a
+// concurrent reschedule. This makes it well suited for code paths where
+// multiple callers race to extend a deadline (e.g. heartbeat resets) but
+// only the caller requesting the soonest deadline should matter.
+type Alarm interface {
+	// C returns a channel where the alarm will send the current time once
+	// it fires.
+	C() <-chan time.Time
+
+	// Schedule reschedules the alarm to fire no later than t. If the alarm
+	// already has an earlier fire time pending, Schedule is a no-op.
+	Schedule(t time.Time)
+
+	// Stop cancels the pending fire, if any.
+	Stop()
+}
+
+// AlarmAt is a convenience helper that creates a new Alarm on c already
+// scheduled to fire at time t.
+func AlarmAt(c Clock, t time.Time) Alarm {
+	return c.NewAlarm(t)
+}
@@ -1,6 +1,7 @@
 package clock
 
 import (
+	"context"
 	"sync"
 	"time"
 )
@@ -14,6 +15,28 @@ type waiter struct {
 	// Set to true when the channel for this waiter has been returned to the
 	// timeout/timer consumer.
 	consumerWaiting bool
+
+	// stepInterval is set for waiters backing a Ticker. Instead of being
+	// removed from fc.waiters once it fires, the waiter is re-armed with
+	// timeout reset to stepInterval so it keeps firing periodically.
+	stepInterval time.Duration
+
+	// isAlarm and nextFire are set for waiters backing an Alarm. nextFire
+	// tracks the absolute deadline the alarm is currently scheduled for so
+	// that Advance can tell a genuine fire apart from one that should be
+	// re-armed because a later Schedule call moved the deadline outward.
+	isAlarm  bool
+	nextFire time.Time
+
+	// fn is set for waiters backing an AfterFunc callback. Instead of
+	// sending on notifyCh, Advance invokes fn in its own goroutine (with
+	// fc.mu released) once the timeout expires, and the waiter is removed.
+	fn func()
+
+	// timerChanExtracted is set for waiters backing a Timer whose C()
+	// method has been called. It mirrors FakeClock.extractedTimerChans so
+	// that firing or stopping the timer can keep the counter in sync.
+	timerChanExtracted bool
 }
 
 // FakeClock is a clock implementation that allows time to be programmatically
@@ -21,13 +44,20 @@ type waiter struct {
 // used as a drop-in replacement for the wall clock in tests.
 type FakeClock struct {
 	mu      sync.Mutex
+	cond    *sync.Cond
 	waiters []*waiter
 	curTime time.Time
+
+	// extractedTimerChans counts timers whose C() has been called but have
+	// not yet fired or been stopped. See NumExtractedTimerChans.
+	extractedTimerChans int
 }
 
 // NewFakeClock creates a new fake clock instance whose time is set to curTime.
 func NewFakeClock(curTime time.Time) *FakeClock {
-	return &FakeClock{curTime: curTime}
+	fc := &FakeClock{curTime: curTime}
+	fc.cond = sync.NewCond(&fc.mu)
+	return fc
 }
 
 // Now returns the current time.
@@ -37,6 +67,11 @@ func (fc *FakeClock) Now() time.Time {
 	return fc.curTime
 }
 
+// Since returns the elapsed time since t.
+func (fc *FakeClock) Since(t time.Time) time.Duration {
+	return fc.Now().Sub(t)
+}
+
 // After waits for the duration to elapse and then sends the current time on
 // the returned channel.
 func (fc *FakeClock) After(d time.Duration) <-chan time.Time {
@@ -45,6 +80,7 @@ func (fc *FakeClock) After(d time.Duration) <-chan time.Time {
 
 	waiter := makeWaiter(d, true)
 	fc.waiters = append(fc.waiters, waiter)
+	fc.cond.Broadcast()
 	return waiter.notifyCh
 }
 
@@ -61,28 +97,96 @@ func (fc *FakeClock) NewTimer(d time.Duration) Timer {
 	return fakeClockTimer{fc: fc, waiter: waiter}
 }
 
+// NewTicker creates a new Ticker that will send the current time on its
+// channel every duration d until stopped.
+func (fc *FakeClock) NewTicker(d time.Duration) Ticker {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	// Tickers behave like timers except that, once their timeout elapses,
+	// the waiter is re-armed with the same interval instead of being
+	// removed from fc.waiters.
+	waiter := makeWaiter(d, false)
+	waiter.stepInterval = d
+	fc.waiters = append(fc.waiters, waiter)
+	return fakeClockTicker{fc: fc, waiter: waiter}
+}
+
+// NewAlarm creates a new Alarm scheduled to fire at time t.
+func (fc *FakeClock) NewAlarm(t time.Time) Alarm {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	waiter := makeWaiter(0, false)
+	waiter.isAlarm = true
+	fc.waiters = append(fc.waiters, waiter)
+
+	alarm := fakeClockAlarm{fc: fc, waiter: waiter}
+	alarm.schedule(t)
+	return alarm
+}
+
+// AfterFunc waits for the duration to elapse and then calls f in its own
+// goroutine. It returns a Timer that can be used to cancel the call using
+// its Stop method.
+func (fc *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	waiter := makeWaiter(d, false)
+	waiter.fn = f
+	fc.waiters = append(fc.waiters, waiter)
+	return fakeClockTimer{fc: fc, waiter: waiter}
+}
+
 // WaitAdvance blocks until at least the requested number of waiters has
 // received back a timeout/timer channel from the clock and then advances the
 // clock by d.
 func (fc *FakeClock) WaitAdvance(numWaiters int, d time.Duration) {
+	// WaitAdvance never times out; a context that is never cancelled makes
+	// WaitAdvanceContext behave the same way.
+	_ = fc.WaitAdvanceContext(context.Background(), numWaiters, d)
+}
+
+// WaitAdvanceContext behaves like WaitAdvance but returns ctx.Err() if ctx is
+// cancelled before the requested number of waiters shows up, instead of
+// blocking forever.
+func (fc *FakeClock) WaitAdvanceContext(ctx context.Context, numWaiters int, d time.Duration) error {
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			fc.mu.Lock()
+			fc.cond.Broadcast()
+			fc.mu.Unlock()
+		case <-stop:
+		}
+	}()
+
+	fc.mu.Lock()
 	for {
-		fc.mu.Lock()
 		var waitingConsumers int
 		for _, w := range fc.waiters {
 			if w.consumerWaiting {
 				waitingConsumers++
 			}
+		}
+		if waitingConsumers >= numWaiters {
+			fc.mu.Unlock()
+			fc.Advance(d)
+			return nil
+		}
 
-			if waitingConsumers == numWaiters {
-				fc.mu.Unlock()
-				fc.Advance(d)
-				return
-			}
+		if err := ctx.Err(); err != nil {
+			fc.mu.Unlock()
+			return err
 		}
-		fc.mu.Unlock()
 
-		// Poll a bit later
-		<-time.After(100 * time.Millisecond)
+		// Released while blocked and re-acquired once Broadcast is called
+		// by After/C() (a new consumer arrived) or by the ctx.Done watcher
+		// above (ctx was cancelled).
+		fc.cond.Wait()
 	}
 }
 
@@ -91,13 +195,27 @@ func (fc *FakeClock) Advance(d time.Duration) {
 	fc.mu.Lock()
 	defer fc.mu.Unlock()
 
-	fc.curTime.Add(d)
+	fc.curTime = fc.curTime.Add(d)
 
-	// Notify and remove expired waiters.
+	// Notify expired waiters and remove them, unless they are backed by a
+	// ticker in which case they get re-armed instead.
 	var activeWaiters []*waiter
 	for _, waiter := range fc.waiters {
-		if waiter.timeout <= d {
-			waiter.timeout = 0
+		if waiter.timeout > d {
+			waiter.timeout -= d
+			activeWaiters = append(activeWaiters, waiter)
+			continue
+		}
+
+		if waiter.stepInterval > 0 {
+			// Figure out how many ticks fit in the elapsed duration
+			// and deliver a single (non-blocking) notification for
+			// them; fast-forwarding the clock past several ticks
+			// should not block nor pile up notifications.
+			elapsed := d - waiter.timeout
+			overshoot := elapsed % waiter.stepInterval
+			waiter.timeout = waiter.stepInterval - overshoot
+
 			select {
 			case waiter.notifyCh <- fc.curTime:
 			default:
@@ -105,14 +223,55 @@ func (fc *FakeClock) Advance(d time.Duration) {
 				// notification yet.  Drop the new one to the
 				// floor.
 			}
+			activeWaiters = append(activeWaiters, waiter)
 			continue
 		}
-		waiter.timeout -= d
-		activeWaiters = append(activeWaiters, waiter)
+
+		if waiter.fn != nil {
+			// Run the callback in its own goroutine so that it never
+			// executes while fc.mu is held, mirroring how time.AfterFunc
+			// callbacks run outside of the timer's internal locks.
+			waiter.timeout = 0
+			go waiter.fn()
+			continue
+		}
+
+		if waiter.isAlarm {
+			// Unlike the wall-clock implementation, no re-arm race is
+			// possible here: fc.mu serializes Advance against Schedule,
+			// and Schedule only ever pulls nextFire inward while a fire
+			// is pending, so by the time a waiter's timeout reaches
+			// zero its nextFire has genuinely been reached.
+			waiter.nextFire = time.Time{}
+		}
+
+		if waiter.timerChanExtracted {
+			fc.extractedTimerChans--
+			waiter.timerChanExtracted = false
+		}
+
+		waiter.timeout = 0
+		select {
+		case waiter.notifyCh <- fc.curTime:
+		default:
+			// Consumer has not received the last
+			// notification yet.  Drop the new one to the
+			// floor.
+		}
 	}
 	fc.waiters = activeWaiters
 }
 
+// NumExtractedTimerChans returns the number of timers whose C() method has
+// been called but that have not yet fired or been stopped. Tests can use
+// this to confirm that a goroutine is parked in its select block on a timer
+// channel before advancing the clock, instead of relying on a time.Sleep.
+func (fc *FakeClock) NumExtractedTimerChans() int {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.extractedTimerChans
+}
+
 func makeWaiter(d time.Duration, consumerWaiting bool) *waiter {
 	return &waiter{
 		timeout:         d,
@@ -121,6 +280,18 @@ func makeWaiter(d time.Duration, consumerWaiting bool) *waiter {
 	}
 }
 
+// reregisterWaiterLocked re-adds w to fc.waiters if it isn't already present,
+// covering the case where the waiter previously fired or was Stop()-ed and
+// is now being re-armed via Reset. Callers must hold fc.mu.
+func reregisterWaiterLocked(fc *FakeClock, w *waiter) {
+	for _, existing := range fc.waiters {
+		if existing == w {
+			return
+		}
+	}
+	fc.waiters = append(fc.waiters, w)
+}
+
 type fakeClockTimer struct {
 	fc     *FakeClock
 	waiter *waiter
@@ -130,7 +301,12 @@ func (ft fakeClockTimer) C() <-chan time.Time {
 	ft.fc.mu.Lock()
 	defer ft.fc.mu.Unlock()
 
-	ft.waiter.consumerWaiting = true
+	if !ft.waiter.consumerWaiting {
+		ft.waiter.consumerWaiting = true
+		ft.waiter.timerChanExtracted = true
+		ft.fc.extractedTimerChans++
+		ft.fc.cond.Broadcast()
+	}
 	return ft.waiter.notifyCh
 }
 
@@ -138,6 +314,7 @@ func (ft fakeClockTimer) Reset(d time.Duration) {
 	ft.fc.mu.Lock()
 	defer ft.fc.mu.Unlock()
 	ft.waiter.timeout = d
+	reregisterWaiterLocked(ft.fc, ft.waiter)
 }
 
 func (ft fakeClockTimer) Stop() bool {
@@ -145,6 +322,11 @@ func (ft fakeClockTimer) Stop() bool {
 	defer ft.fc.mu.Unlock()
 
 	alreadyFired := ft.waiter.timeout == 0
+	if ft.waiter.timerChanExtracted {
+		ft.fc.extractedTimerChans--
+		ft.waiter.timerChanExtracted = false
+	}
+
 	var activeWaiters []*waiter
 	for _, w := range ft.fc.waiters {
 		if w == ft.waiter {
@@ -156,3 +338,98 @@ func (ft fakeClockTimer) Stop() bool {
 
 	return alreadyFired
 }
+
+type fakeClockTicker struct {
+	fc     *FakeClock
+	waiter *waiter
+}
+
+func (ft fakeClockTicker) C() <-chan time.Time {
+	ft.fc.mu.Lock()
+	defer ft.fc.mu.Unlock()
+
+	if !ft.waiter.consumerWaiting {
+		ft.waiter.consumerWaiting = true
+		ft.fc.cond.Broadcast()
+	}
+	return ft.waiter.notifyCh
+}
+
+func (ft fakeClockTicker) Reset(d time.Duration) {
+	ft.fc.mu.Lock()
+	defer ft.fc.mu.Unlock()
+	ft.waiter.timeout = d
+	ft.waiter.stepInterval = d
+	reregisterWaiterLocked(ft.fc, ft.waiter)
+}
+
+func (ft fakeClockTicker) Stop() {
+	ft.fc.mu.Lock()
+	defer ft.fc.mu.Unlock()
+
+	var activeWaiters []*waiter
+	for _, w := range ft.fc.waiters {
+		if w == ft.waiter {
+			continue
+		}
+		activeWaiters = append(activeWaiters, w)
+	}
+	ft.fc.waiters = activeWaiters
+}
+
+type fakeClockAlarm struct {
+	fc     *FakeClock
+	waiter *waiter
+}
+
+func (fa fakeClockAlarm) C() <-chan time.Time {
+	fa.fc.mu.Lock()
+	defer fa.fc.mu.Unlock()
+
+	if !fa.waiter.consumerWaiting {
+		fa.waiter.consumerWaiting = true
+		fa.fc.cond.Broadcast()
+	}
+	return fa.waiter.notifyCh
+}
+
+func (fa fakeClockAlarm) Schedule(t time.Time) {
+	fa.fc.mu.Lock()
+	defer fa.fc.mu.Unlock()
+	fa.schedule(t)
+}
+
+// schedule is the lock-free core of Schedule, also used by FakeClock.NewAlarm
+// while the caller already holds fc.mu.
+func (fa fakeClockAlarm) schedule(t time.Time) {
+	if !fa.waiter.nextFire.IsZero() && !t.Before(fa.waiter.nextFire) {
+		// An earlier (or equal) fire time is already pending; only pulls
+		// inward are allowed to reschedule.
+		return
+	}
+	fa.waiter.nextFire = t
+
+	timeout := t.Sub(fa.fc.curTime)
+	if timeout < 0 {
+		timeout = 0
+	}
+	fa.waiter.timeout = timeout
+
+	// Stop() may have removed the waiter from the list; re-register it.
+	reregisterWaiterLocked(fa.fc, fa.waiter)
+}
+
+func (fa fakeClockAlarm) Stop() {
+	fa.fc.mu.Lock()
+	defer fa.fc.mu.Unlock()
+
+	fa.waiter.nextFire = time.Time{}
+	var activeWaiters []*waiter
+	for _, w := range fa.fc.waiters {
+		if w == fa.waiter {
+			continue
+		}
+		activeWaiters = append(activeWaiters, w)
+	}
+	fa.fc.waiters = activeWaiters
+}
@@ -1,11 +1,15 @@
 package clock
 
 import (
+	"context"
+	"testing"
 	"time"
 
 	gc "gopkg.in/check.v1"
 )
 
+func TestClock(t *testing.T) { gc.TestingT(t) }
+
 var _ = gc.Suite(&fakeClockSuite{})
 
 type fakeClockSuite struct {
@@ -20,6 +24,7 @@ func (fakeClockSuite) TestGetCurrentTime(c *gc.C) {
 	advance := 90 * time.Second
 	now = now.Add(advance)
 	clk.Advance(advance)
+	c.Assert(clk.Now(), gc.DeepEquals, now)
 }
 
 func (fakeClockSuite) TestAdvanceTriggersTimeout(c *gc.C) {
@@ -75,6 +80,113 @@ func (fakeClockSuite) TestTimer(c *gc.C) {
 	c.Assert(fired, gc.Equals, false, gc.Commentf("expected Stop() to return false when the timer has not yet fired"))
 }
 
+func (fakeClockSuite) TestTicker(c *gc.C) {
+	clk := NewFakeClock(time.Now())
+	ticker := clk.NewTicker(10 * time.Minute)
+	tickerCh := ticker.C()
+
+	// Advancing past a single interval should deliver exactly one tick and
+	// leave the ticker armed for the next one.
+	clk.Advance(10 * time.Minute)
+	select {
+	case <-tickerCh:
+	case <-time.After(3 * time.Second):
+		c.Error("timeout waiting for first tick")
+	}
+
+	clk.Advance(10 * time.Minute)
+	select {
+	case <-tickerCh:
+	case <-time.After(3 * time.Second):
+		c.Error("timeout waiting for second tick")
+	}
+
+	// The waiter backing the ticker should still be tracked by the clock.
+	clk.mu.Lock()
+	numWaiters := len(clk.waiters)
+	clk.mu.Unlock()
+	c.Assert(numWaiters, gc.Equals, 1, gc.Commentf("expected ticker waiter to remain registered after firing"))
+
+	ticker.Stop()
+	clk.mu.Lock()
+	numWaiters = len(clk.waiters)
+	clk.mu.Unlock()
+	c.Assert(numWaiters, gc.Equals, 0, gc.Commentf("expected ticker waiter to be removed after Stop"))
+}
+
+func (fakeClockSuite) TestAlarmEarlyOnlyReset(c *gc.C) {
+	now := time.Now()
+	clk := NewFakeClock(now)
+
+	alarm := clk.NewAlarm(now.Add(10 * time.Minute))
+
+	// Pushing the deadline further out must be a no-op: advancing past the
+	// original (earlier) deadline should still fire the alarm.
+	alarm.Schedule(now.Add(20 * time.Minute))
+	clk.Advance(10 * time.Minute)
+
+	select {
+	case <-alarm.C():
+	case <-time.After(3 * time.Second):
+		c.Error("timeout waiting for alarm to fire at the original, earlier deadline")
+	}
+}
+
+func (fakeClockSuite) TestAlarmPullsDeadlineIn(c *gc.C) {
+	now := time.Now()
+	clk := NewFakeClock(now)
+
+	alarm := clk.NewAlarm(now.Add(20 * time.Minute))
+
+	// Pulling the deadline in must take effect: the alarm should now fire
+	// after only 5 minutes rather than the original 20.
+	alarm.Schedule(now.Add(5 * time.Minute))
+	clk.Advance(5 * time.Minute)
+
+	select {
+	case <-alarm.C():
+	case <-time.After(3 * time.Second):
+		c.Error("timeout waiting for alarm to fire at the pulled-in deadline")
+	}
+}
+
+func (fakeClockSuite) TestAlarmStop(c *gc.C) {
+	clk := NewFakeClock(time.Now())
+	alarm := clk.NewAlarm(clk.Now().Add(10 * time.Minute))
+
+	alarm.Stop()
+	clk.mu.Lock()
+	numWaiters := len(clk.waiters)
+	clk.mu.Unlock()
+	c.Assert(numWaiters, gc.Equals, 0, gc.Commentf("expected alarm waiter to be removed after Stop"))
+
+	// Rescheduling after Stop should re-register the alarm.
+	alarm.Schedule(clk.Now().Add(5 * time.Minute))
+	clk.Advance(5 * time.Minute)
+	select {
+	case <-alarm.C():
+	case <-time.After(3 * time.Second):
+		c.Error("timeout waiting for alarm to fire after being rescheduled post-Stop")
+	}
+}
+
+func (fakeClockSuite) TestAfterFunc(c *gc.C) {
+	clk := NewFakeClock(time.Now())
+
+	firedCh := make(chan struct{})
+	timer := clk.AfterFunc(10*time.Minute, func() { close(firedCh) })
+
+	clk.Advance(10 * time.Minute)
+	select {
+	case <-firedCh:
+	case <-time.After(3 * time.Second):
+		c.Error("timeout waiting for AfterFunc callback to run")
+	}
+
+	fired := timer.Stop()
+	c.Assert(fired, gc.Equals, true, gc.Commentf("expected Stop() to return true once the callback has already run"))
+}
+
 func (fakeClockSuite) TestWaitAdvance(c *gc.C) {
 	clk := NewFakeClock(time.Now())
 
@@ -111,3 +223,49 @@ func (fakeClockSuite) TestWaitAdvance(c *gc.C) {
 		c.Error("timeout waiting for notification on clock.NewTimer().C() result")
 	}
 }
+
+func (fakeClockSuite) TestWaitAdvanceContextCancellation(c *gc.C) {
+	clk := NewFakeClock(time.Now())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		// No consumer will ever show up, so this would block forever
+		// without the ctx cancellation below.
+		errCh <- clk.WaitAdvanceContext(ctx, 1, 10*time.Minute)
+	}()
+
+	cancel()
+	select {
+	case err := <-errCh:
+		c.Assert(err, gc.Equals, context.Canceled)
+	case <-time.After(3 * time.Second):
+		c.Error("timeout waiting for WaitAdvanceContext to return after cancellation")
+	}
+}
+
+func (fakeClockSuite) TestNumExtractedTimerChans(c *gc.C) {
+	clk := NewFakeClock(time.Now())
+	timer := clk.NewTimer(10 * time.Minute)
+
+	c.Assert(clk.NumExtractedTimerChans(), gc.Equals, 0)
+
+	ch := timer.C()
+	c.Assert(clk.NumExtractedTimerChans(), gc.Equals, 1)
+
+	// Calling C() again must not double-count.
+	_ = timer.C()
+	c.Assert(clk.NumExtractedTimerChans(), gc.Equals, 1)
+
+	clk.Advance(10 * time.Minute)
+	<-ch
+	c.Assert(clk.NumExtractedTimerChans(), gc.Equals, 0, gc.Commentf("expected counter to be decremented once the timer fired"))
+
+	timer2 := clk.NewTimer(10 * time.Minute)
+	_ = timer2.C()
+	c.Assert(clk.NumExtractedTimerChans(), gc.Equals, 1)
+	timer2.Stop()
+	c.Assert(clk.NumExtractedTimerChans(), gc.Equals, 0, gc.Commentf("expected counter to be decremented once the timer was stopped"))
+}
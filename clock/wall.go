@@ -1,6 +1,9 @@
 package clock
 
-import "time"
+import (
+	"sync"
+	"time"
+)
 
 // WallClock implements a clock using the time package from the Go standard library.
 var WallClock Clock = wallClock{}
@@ -10,6 +13,9 @@ type wallClock struct{}
 // Now returns the current time.
 func (wallClock) Now() time.Time { return time.Now() }
 
+// Since returns the elapsed time since t.
+func (wallClock) Since(t time.Time) time.Duration { return time.Since(t) }
+
 // After waits for the duration to elapse and then sends the current time on
 // the returned channel.
 func (wallClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
@@ -20,6 +26,26 @@ func (wallClock) NewTimer(d time.Duration) Timer {
 	return wallClockTimer{time.NewTimer(d)}
 }
 
+// NewTicker creates a new Ticker that will send the current time on its
+// channel every duration d until stopped.
+func (wallClock) NewTicker(d time.Duration) Ticker {
+	return wallClockTicker{time.NewTicker(d)}
+}
+
+// NewAlarm creates a new Alarm scheduled to fire at time t.
+func (wallClock) NewAlarm(t time.Time) Alarm {
+	a := &wallClockAlarm{c: make(chan time.Time, 1)}
+	a.Schedule(t)
+	return a
+}
+
+// AfterFunc waits for the duration to elapse and then calls f in its own
+// goroutine. It returns a Timer that can be used to cancel the call using
+// its Stop method.
+func (wallClock) AfterFunc(d time.Duration, f func()) Timer {
+	return wallClockTimer{time.AfterFunc(d, f)}
+}
+
 type wallClockTimer struct {
 	t *time.Timer
 }
@@ -27,3 +53,76 @@ type wallClockTimer struct {
 func (wt wallClockTimer) C() <-chan time.Time   { return wt.t.C }
 func (wt wallClockTimer) Reset(d time.Duration) { _ = wt.t.Reset(d) }
 func (wt wallClockTimer) Stop() bool            { return wt.t.Stop() }
+
+type wallClockTicker struct {
+	t *time.Ticker
+}
+
+func (wt wallClockTicker) C() <-chan time.Time   { return wt.t.C }
+func (wt wallClockTicker) Reset(d time.Duration) { wt.t.Reset(d) }
+func (wt wallClockTicker) Stop()                 { wt.t.Stop() }
+
+// wallClockAlarm implements Alarm on top of a single *time.Timer, tracking
+// nextFire so that only reschedules that pull the deadline inward touch the
+// underlying timer.
+type wallClockAlarm struct {
+	mu       sync.Mutex
+	timer    *time.Timer
+	nextFire time.Time
+	c        chan time.Time
+}
+
+func (a *wallClockAlarm) C() <-chan time.Time { return a.c }
+
+func (a *wallClockAlarm) Schedule(t time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.nextFire.IsZero() && !t.Before(a.nextFire) {
+		// An earlier (or equal) fire time is already pending; only pulls
+		// inward are allowed to touch the underlying timer.
+		return
+	}
+	a.nextFire = t
+
+	d := time.Until(t)
+	if a.timer == nil {
+		a.timer = time.AfterFunc(d, a.fire)
+		return
+	}
+	a.timer.Reset(d)
+}
+
+func (a *wallClockAlarm) fire() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.nextFire.IsZero() {
+		// Stop ran after this callback was triggered; nothing is pending.
+		return
+	}
+
+	if !time.Now().Before(a.nextFire) {
+		a.nextFire = time.Time{}
+		select {
+		case a.c <- time.Now():
+		default:
+		}
+		return
+	}
+
+	// This callback was triggered for an earlier arming of the timer, and
+	// Stop followed by a later Schedule ran before it got the lock; re-arm
+	// for the current deadline instead of firing early.
+	a.timer.Reset(time.Until(a.nextFire))
+}
+
+func (a *wallClockAlarm) Stop() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.timer != nil {
+		a.timer.Stop()
+	}
+	a.nextFire = time.Time{}
+}